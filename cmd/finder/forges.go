@@ -0,0 +1,140 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/gofrs/help-requests/internal/forge"
+	"github.com/gofrs/help-requests/internal/httpx"
+	"github.com/google/go-github/github"
+	"github.com/xanzy/go-gitlab"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+var (
+	flagForges   = flag.String("forge", "github", "Comma-separated list of forges to search: github, gitlab, gitea")
+	flagNoCache  = flag.Bool("no-cache", false, "Disable the on-disk HTTP cache")
+	flagCacheTTL = flag.Duration("cache-ttl", 24*time.Hour, "How long to trust cached responses that lack an ETag/Last-Modified (e.g. importer pages)")
+)
+
+// forgeLimiters keeps one forge's HTTP traffic from tripping another's
+// rate limits; each forge gets its own bucket.
+var forgeLimiters = map[string]*rate.Limiter{
+	"github": rate.NewLimiter(rate.Limit(2), 2),
+	"gitlab": rate.NewLimiter(rate.Limit(2), 2),
+	"gitea":  rate.NewLimiter(rate.Limit(2), 2),
+}
+
+// createForges builds a Forge for each name in --forge whose
+// credentials are available in the environment, skipping (with a
+// warning) any that aren't configured rather than failing the whole
+// run.
+func createForges() ([]forge.Forge, error) {
+	var forges []forge.Forge
+	for _, name := range strings.Split(*flagForges, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		f, err := createForge(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: skipping forge %q: %v\n", name, err)
+			continue
+		}
+		forges = append(forges, f)
+	}
+	if len(forges) == 0 {
+		return nil, fmt.Errorf("no forges were configured from --forge=%q", *flagForges)
+	}
+	return forges, nil
+}
+
+func createForge(name string) (forge.Forge, error) {
+	switch name {
+	case "github":
+		return createGitHubForge()
+	case "gitlab":
+		return createGitLabForge()
+	case "gitea":
+		return createGiteaForge()
+	default:
+		return nil, fmt.Errorf("unknown forge %q", name)
+	}
+}
+
+func forgeTransport(name string, base http.RoundTripper) (http.RoundTripper, error) {
+	limited := &httpx.RateLimitedTransport{Limiter: forgeLimiters[name], Transport: base}
+	return httpx.WrapWithCache(limited, *flagNoCache, *flagCacheTTL)
+}
+
+func createGitHubForge() (forge.Forge, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("environment variable GITHUB_TOKEN is required")
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	tc := oauth2.NewClient(context.Background(), ts)
+
+	transport, err := forgeTransport("github", tc.Transport)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: problem setting up github HTTP cache, continuing uncached: %v\n", err)
+	}
+	tc.Transport = transport
+
+	return forge.NewGitHubForge(github.NewClient(tc)), nil
+}
+
+func createGitLabForge() (forge.Forge, error) {
+	token := os.Getenv("GITLAB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("environment variable GITLAB_TOKEN is required")
+	}
+
+	transport, err := forgeTransport("gitlab", http.DefaultTransport)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: problem setting up gitlab HTTP cache, continuing uncached: %v\n", err)
+	}
+
+	client, err := gitlab.NewClient(token, gitlab.WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		return nil, fmt.Errorf("creating gitlab client: %v", err)
+	}
+	return forge.NewGitLabForge(client), nil
+}
+
+func createGiteaForge() (forge.Forge, error) {
+	token := os.Getenv("GITEA_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("environment variable GITEA_TOKEN is required")
+	}
+	baseURL := os.Getenv("GITEA_URL")
+	if baseURL == "" {
+		return nil, fmt.Errorf("environment variable GITEA_URL is required")
+	}
+
+	transport, err := forgeTransport("gitea", http.DefaultTransport)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: problem setting up gitea HTTP cache, continuing uncached: %v\n", err)
+	}
+
+	client, err := gitea.NewClient(baseURL,
+		gitea.SetToken(token),
+		gitea.SetHTTPClient(&http.Client{Transport: transport}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating gitea client: %v", err)
+	}
+	return forge.NewGiteaForge(client), nil
+}
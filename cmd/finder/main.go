@@ -3,150 +3,278 @@
 // license that can be found in the LICENSE file.
 
 // finder is a command line tool (CLI) used to find stale projects
-// on Github (those without recent commits, issues, etc) and rank
-// them by their godoc import counts.
+// across one or more Git forges (those without recent commits,
+// issues, etc) and rank them by their import counts.
 //
-// Godoc.org import counts are public and computed by godoc.org as
-// it indexes the public Go repositories.
+// Import counts come from pkg.go.dev (or, optionally, the public
+// deps.dev BigQuery dataset) since godoc.org has been retired.
 package main // import "github.com/gofrs/help-requests/cmd/finder
 
 import (
 	"context"
-	"errors"
 	"flag"
 	"fmt"
-	"math"
 	"net/http"
 	"os"
+	"path/filepath"
+	"runtime"
 	"sort"
-	"strconv"
-	"strings"
 	"text/tabwriter"
 	"time"
 
-	"github.com/google/go-github/github"
-	"golang.org/x/net/html"
-	"golang.org/x/oauth2"
+	"github.com/gofrs/help-requests/internal/forge"
+	"github.com/gofrs/help-requests/internal/httpx"
+	"github.com/gofrs/help-requests/internal/importers"
+	"github.com/gofrs/help-requests/internal/maintainer"
+	"github.com/gofrs/help-requests/internal/staleness"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 )
 
 var (
-	flagCount = flag.Int("count", 25, "How many (Github) projects to lookup")
+	flagCount           = flag.Int("count", 25, "How many projects to lookup per forge")
+	flagBQProject       = flag.String("bq-project", "", "GCP project to bill BigQuery importer-count queries to (uses pkg.go.dev scraping if unset)")
+	flagWorkers         = flag.Int("workers", runtime.GOMAXPROCS(0), "How many repos to enrich concurrently")
+	flagStalenessConfig = flag.String("staleness-config", "", "Path to a YAML or JSON file of staleness.Weights (uses staleness.DefaultWeights if unset)")
+	flagSortBy          = flag.String("sort-by", "importers", "Column to sort the output by: staleness, importers, or stars")
+
+	flagFileIssues      = flag.Bool("file-issues", false, "File a 'seeking a new maintainer' issue on stale repos at or above --importer-threshold")
+	flagImporterThresh  = flag.Int("importer-threshold", 50, "Minimum importer count required before --file-issues will open an issue")
+	flagMaxIssuesPerRun = flag.Int("max-issues-per-run", 5, "Maximum number of new issues --file-issues will open in a single run")
+	flagIssueLedger     = flag.String("issue-ledger", "", "Path to the JSON ledger of already-filed issues (defaults to a file under the HTTP cache directory)")
+	flagIReallyMeanIt   = flag.Bool("i-really-mean-it", false, "Required alongside --file-issues to confirm finder should actually open issues on other people's repos")
 )
 
+// importerLimiter keeps us from hammering pkg.go.dev while we fan out
+// across --workers goroutines.
+var importerLimiter = rate.NewLimiter(rate.Limit(5), 5)
+
 func main() {
 	flag.Parse()
 
-	ghClient, err := createGithubClient(context.Background())
+	forges, err := createForges()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: problem creating github client: %v", err)
+		fmt.Fprintf(os.Stderr, "ERROR: problem creating forges: %v\n", err)
+		os.Exit(1)
 	}
 
-	opts := github.ListOptions{
-		PerPage: *flagCount,
-		Page:    1,
+	importCounter, err := createImportCounter()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: problem creating importer counter: %v", err)
 	}
 
-	query := "stars:>100 pushed:<2018-01-01 language:Go"
-	repoRes, res, err := ghClient.Search.Repositories(context.Background(), query, &github.SearchOptions{
-		Sort:        "stars",
-		Order:       "desc",
-		ListOptions: opts,
-	})
+	scorer, err := createScorer()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: problem reading github repositories: %v", err)
+		fmt.Fprintf(os.Stderr, "ERROR: problem creating staleness scorer: %v", err)
+	}
+
+	filer, err := createFiler(forges)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: problem setting up --file-issues: %v\n", err)
+		os.Exit(1)
+	}
+
+	query := forge.Query{
+		Language:     "Go",
+		MinStars:     100,
+		PushedBefore: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		Limit:        *flagCount,
+	}
+
+	type sourcedRepo struct {
+		forge forge.Forge
+		repo  forge.Repo
+	}
+	var sourced []sourcedRepo
+	for _, f := range forges {
+		repos, err := f.SearchRepos(context.Background(), query)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: problem searching %s repositories: %v\n", f.Name(), err)
+			continue
+		}
+		for _, r := range repos {
+			sourced = append(sourced, sourcedRepo{forge: f, repo: r})
+		}
 	}
-	res.Close = true
 
 	type row struct {
+		name        string
 		text        string
+		stars       int
 		importCount int
+		staleness   float64
+		skip        bool // repo is archived; staleness.Scorer doesn't apply
 	}
-	var rows []row
-	for i := range repoRes.Repositories {
-		repo := repoRes.Repositories[i]
+	rows := make([]row, len(sourced))
 
-		cleanName := strings.Replace(*repo.HTMLURL, `https://`, "", 1)
+	workers := *flagWorkers
+	if workers < 1 {
+		workers = 1
+	}
 
-		// TODO(adam): goroutines + sync.WaitGroup
-		importers, err := scrapeGodocImports(cleanName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "ERROR: problem grabbing %s godoc importers: %v\n", cleanName, err)
-		}
+	g, gctx := errgroup.WithContext(context.Background())
+	sem := make(chan struct{}, workers)
+	for i := range sourced {
+		i, sr := i, sourced[i]
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			importCount, err := importCounter.Count(gctx, sr.repo.HTMLURL)
+			errText := ""
+			if err != nil {
+				importCount = -1
+				errText = err.Error()
+			}
+
+			stats, err := sr.forge.RepoStats(gctx, sr.repo)
+			if err != nil {
+				if errText != "" {
+					errText += "; "
+				}
+				errText += err.Error()
+			}
+			score := scorer.Score(stats)
 
-		days := int(math.Abs(float64(repo.PushedAt.Sub(time.Now()).Hours()) / 24.0))
-		line := fmt.Sprintf("%s\t%d\t%d\t%d\n", cleanName, *repo.StargazersCount, days, importers)
-		rows = append(rows, row{
-			text:        line,
-			importCount: importers,
+			if filer != nil {
+				filed, ferr := filer.FileIfStale(gctx, sr.repo, importCount)
+				switch {
+				case ferr != nil:
+					if errText != "" {
+						errText += "; "
+					}
+					errText += ferr.Error()
+				case filed:
+					if errText != "" {
+						errText += "; "
+					}
+					errText += "filed help-wanted issue"
+				}
+			}
+
+			rows[i] = row{
+				name:        sr.repo.HTMLURL,
+				text:        fmt.Sprintf("%s\t%s\t%d\t%d\t%d\t%.1f\t%s\n", sr.repo.Forge, sr.repo.HTMLURL, sr.repo.Stars, stats.DaysSinceLastCommit, importCount, score, errText),
+				stars:       sr.repo.Stars,
+				importCount: importCount,
+				staleness:   score,
+				skip:        stats.Archived,
+			}
+			return nil // per-repo errors are surfaced in the table, not treated as fatal
 		})
 	}
-	sort.Slice(rows, func(i, j int) bool { return rows[i].importCount < rows[j].importCount })
+	if err := g.Wait(); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: problem enriching repositories: %v\n", err)
+	}
+
+	kept := rows[:0]
+	for _, r := range rows {
+		if !r.skip {
+			kept = append(kept, r)
+		}
+	}
+	rows = kept
+
+	sort.Slice(rows, func(i, j int) bool {
+		switch *flagSortBy {
+		case "staleness":
+			if rows[i].staleness != rows[j].staleness {
+				return rows[i].staleness < rows[j].staleness
+			}
+		case "stars":
+			if rows[i].stars != rows[j].stars {
+				return rows[i].stars < rows[j].stars
+			}
+		default: // "importers"
+			if rows[i].importCount != rows[j].importCount {
+				return rows[i].importCount < rows[j].importCount
+			}
+		}
+		return rows[i].name > rows[j].name
+	})
 
 	// Write (sorted) output
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "name\tstars\tlast commit (days)\timporters\n")
+	fmt.Fprintf(w, "forge\tname\tstars\tlast commit (days)\timporters\tstaleness\terror\n")
 	defer w.Flush()
 	for i := range rows {
 		// we're going to write the rows in reverse
 		// this will output them in desc order
-		fmt.Fprintf(w, rows[len(rows)-i-1].text)
+		fmt.Fprint(w, rows[len(rows)-i-1].text)
 	}
 }
 
-func createGithubClient(ctx context.Context) (*github.Client, error) {
-	v := os.Getenv("GITHUB_TOKEN")
-	if v == "" {
-		return nil, errors.New("environment variable GITHUB_TOKEN is required")
+// createScorer builds the staleness.Scorer used to rank repos. If
+// --staleness-config is set its weights are loaded from that file;
+// otherwise staleness.DefaultWeights is used.
+func createScorer() (staleness.Scorer, error) {
+	weights := staleness.DefaultWeights()
+	if *flagStalenessConfig != "" {
+		var err error
+		weights, err = staleness.LoadWeights(*flagStalenessConfig)
+		if err != nil {
+			return staleness.NewWeightedScorer(staleness.DefaultWeights()), err
+		}
 	}
-	ts := oauth2.StaticTokenSource(&oauth2.Token{
-		AccessToken: v,
-	})
-	tc := oauth2.NewClient(ctx, ts)
-	return github.NewClient(tc), nil
+	return staleness.NewWeightedScorer(weights), nil
 }
 
-func scrapeGodocImports(importPath string) (int, error) {
-	req, err := http.NewRequest("GET", "https://godoc.org/"+importPath, nil)
-	if err != nil {
-		return -1, fmt.Errorf("problem loading godoc.org: %v", err)
+// createFiler builds the maintainer.Filer used by --file-issues, or
+// returns (nil, nil) if the flag wasn't set. --i-really-mean-it is a
+// required safeguard since this mode opens issues on other people's
+// repos.
+func createFiler(forges []forge.Forge) (*maintainer.Filer, error) {
+	if !*flagFileIssues {
+		return nil, nil
+	}
+	if !*flagIReallyMeanIt {
+		return nil, fmt.Errorf("--file-issues requires --i-really-mean-it")
 	}
-	req.Header.Set("User-Agent", "Gofrs popstalerepo bot")
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return -1, fmt.Errorf("problem loading %s: %v", req.URL, err)
-	}
-	defer resp.Body.Close()
-
-	// recursive search, from /x/net/html docs
-	var f func(n *html.Node) (int, error)
-	f = func(n *html.Node) (int, error) {
-		if n.Type == html.ElementNode && n.Data == "a" {
-			for _, a := range n.Attr {
-				// TODO(adam): we should try and refresh importers
-				// when running into errors.
-				if a.Key == "href" && strings.Contains(a.Val, "?importers") {
-					parts := strings.Fields(n.FirstChild.Data)
-					n, err := strconv.Atoi(parts[0])
-					if err != nil {
-						return -1, fmt.Errorf("couldn't parse %q: %v", parts[0], err)
-					}
-					return n, nil
-				}
-			}
+	ledgerPath := *flagIssueLedger
+	if ledgerPath == "" {
+		dir, err := httpx.CacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("determining default --issue-ledger path: %v", err)
 		}
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			n, err := f(c)
-			if err == nil && n > 0 {
-				return n, err
-			}
+		ledgerPath = filepath.Join(dir, "filed-issues.json")
+	}
+	ledger, err := maintainer.LoadLedger(ledgerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	filerForges := map[string]forge.IssueFiler{}
+	for _, f := range forges {
+		if issueFiler, ok := f.(forge.IssueFiler); ok {
+			filerForges[f.Name()] = issueFiler
 		}
-		return -1, errors.New(`didn't find <a href="?importers">`)
 	}
 
-	doc, err := html.Parse(resp.Body)
+	return &maintainer.Filer{
+		Forges:            filerForges,
+		Ledger:            ledger,
+		ImporterThreshold: *flagImporterThresh,
+		MaxPerRun:         *flagMaxIssuesPerRun,
+	}, nil
+}
+
+// createImportCounter builds the importers.Counter used to rank
+// repos. If --bq-project is set it queries the public deps.dev
+// BigQuery dataset; otherwise it falls back to scraping pkg.go.dev.
+func createImportCounter() (importers.Counter, error) {
+	limited := &httpx.RateLimitedTransport{Limiter: importerLimiter, Transport: http.DefaultTransport}
+	transport, err := httpx.WrapWithCache(limited, *flagNoCache, *flagCacheTTL)
 	if err != nil {
-		return -1, fmt.Errorf("couldn't parse html: %v", err)
+		fmt.Fprintf(os.Stderr, "WARNING: problem setting up importer HTTP cache, continuing uncached: %v\n", err)
+	}
+	pkgDevClient := &http.Client{Transport: transport}
+	if *flagBQProject != "" {
+		counter, err := importers.NewBigQueryCounter(context.Background(), *flagBQProject)
+		if err != nil {
+			return importers.NewPkgGoDevCounter(pkgDevClient), fmt.Errorf("falling back to pkg.go.dev: %v", err)
+		}
+		return counter, nil
 	}
-	return f(doc)
+	return importers.NewPkgGoDevCounter(pkgDevClient), nil
 }
@@ -0,0 +1,91 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+// Package forge abstracts over the Git hosting services finder can
+// search for stale, popular Go repositories, so it isn't hard-coded
+// to GitHub.
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/help-requests/internal/staleness"
+)
+
+// responseWindow is how long an open issue can go without any
+// comment before it counts as unanswered.
+const responseWindow = 14 * 24 * time.Hour
+
+// Query describes the repository search finder runs against each
+// configured Forge.
+type Query struct {
+	Language     string
+	MinStars     int
+	PushedBefore time.Time
+	Limit        int
+}
+
+// Repo is a forge-agnostic view of a single search result.
+type Repo struct {
+	Forge    string // "github", "gitlab", or "gitea"
+	FullName string
+	HTMLURL  string
+	Stars    int
+	PushedAt time.Time
+	Archived bool
+	// Disabled means the host itself has disabled the repo (e.g. a
+	// DMCA takedown or billing suspension), distinct from the owner
+	// archiving it voluntarily. Not currently populated by any Forge
+	// implementation: GitHub's SDK is pinned to the unversioned
+	// github.com/google/go-github/github import path, whose last
+	// release (v17) predates the API's disabled flag.
+	Disabled bool
+
+	// Native holds the forge-specific result (e.g. *github.Repository)
+	// backing this Repo. Only the Forge that produced it via
+	// SearchRepos knows how to read it back in RepoStats.
+	Native interface{}
+}
+
+// Forge is a Git hosting service finder can search for stale,
+// popular Go repositories.
+type Forge interface {
+	// Name identifies the forge, e.g. "github", "gitlab", "gitea".
+	Name() string
+	// SearchRepos returns repos matching q, sorted by stars desc.
+	SearchRepos(ctx context.Context, q Query) ([]Repo, error)
+	// RepoStats gathers the staleness signals for a Repo that this
+	// same Forge produced via SearchRepos.
+	RepoStats(ctx context.Context, repo Repo) (staleness.Stats, error)
+}
+
+// IssueFiler is implemented by Forges that can open "help wanted"
+// issues on repos they produced via SearchRepos. Not every Forge
+// implementation needs to support this, so it's kept separate from
+// the base Forge interface.
+type IssueFiler interface {
+	// HasOpenIssue reports whether an issue titled title, filed by
+	// this tool's own account, already exists on repo.
+	HasOpenIssue(ctx context.Context, repo Repo, title string) (bool, error)
+	// FileIssue opens a new issue titled title with the given body.
+	FileIssue(ctx context.Context, repo Repo, title, body string) error
+}
+
+func splitFullName(fullName string) (owner, name string, err error) {
+	parts := strings.SplitN(fullName, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("unexpected repository full name %q", fullName)
+	}
+	return parts[0], parts[1], nil
+}
+
+func daysSince(t time.Time) int {
+	if t.IsZero() {
+		return -1
+	}
+	return int(time.Since(t).Hours() / 24.0)
+}
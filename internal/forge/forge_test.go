@@ -0,0 +1,109 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/google/go-github/github"
+	"github.com/xanzy/go-gitlab"
+)
+
+// These are smoke tests: each points a real forge client at a fake
+// HTTP server standing in for GitHub/GitLab/Gitea, and checks that
+// SearchRepos parses a result without panicking or erroring. The
+// GitLab test also checks that Language is sent as a search
+// parameter, and the Gitea test that Language is matched client-side.
+
+func TestGitHubForgeSearchRepos(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"total_count": 1, "items": [
+			{"full_name": "example/stale", "html_url": "https://github.com/example/stale",
+			 "stargazers_count": 500, "pushed_at": "2017-01-01T00:00:00Z", "archived": false}
+		]}`))
+	}))
+	defer srv.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	repos, err := NewGitHubForge(client).SearchRepos(context.Background(), Query{
+		Language: "Go", MinStars: 100, PushedBefore: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("SearchRepos: %v", err)
+	}
+	if len(repos) != 1 || repos[0].FullName != "example/stale" {
+		t.Errorf("SearchRepos = %+v, want one repo named example/stale", repos)
+	}
+}
+
+func TestGitLabForgeSearchReposFiltersByLanguage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("with_programming_language"); got != "Go" {
+			t.Errorf("request missing with_programming_language=Go, got %q", got)
+		}
+		w.Write([]byte(`[
+			{"id": 1, "path_with_namespace": "example/stale", "web_url": "https://gitlab.com/example/stale",
+			 "star_count": 200, "last_activity_at": "2017-01-01T00:00:00Z", "archived": false}
+		]`))
+	}))
+	defer srv.Close()
+
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("gitlab.NewClient: %v", err)
+	}
+
+	repos, err := NewGitLabForge(client).SearchRepos(context.Background(), Query{
+		Language: "Go", MinStars: 100, PushedBefore: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("SearchRepos: %v", err)
+	}
+	if len(repos) != 1 || repos[0].FullName != "example/stale" {
+		t.Errorf("SearchRepos = %+v, want one repo named example/stale", repos)
+	}
+}
+
+func TestGiteaForgeSearchReposFiltersByLanguage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// gitea.NewClient probes /api/v1/version before issuing any
+		// other request, so the fake server needs to answer it too.
+		if strings.HasSuffix(r.URL.Path, "/version") {
+			w.Write([]byte(`{"version": "1.20.0"}`))
+			return
+		}
+		w.Write([]byte(`{"ok": true, "data": [
+			{"full_name": "example/stale", "html_url": "https://git.example.com/example/stale",
+			 "stars_count": 150, "updated_at": "2017-01-01T00:00:00Z", "archived": false, "language": "Go"},
+			{"full_name": "example/other-lang", "html_url": "https://git.example.com/example/other-lang",
+			 "stars_count": 150, "updated_at": "2017-01-01T00:00:00Z", "archived": false, "language": "Python"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	client, err := gitea.NewClient(srv.URL)
+	if err != nil {
+		t.Fatalf("gitea.NewClient: %v", err)
+	}
+
+	repos, err := NewGiteaForge(client).SearchRepos(context.Background(), Query{
+		Language: "Go", MinStars: 100, PushedBefore: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("SearchRepos: %v", err)
+	}
+	if len(repos) != 1 || repos[0].FullName != "example/stale" {
+		t.Errorf("SearchRepos = %+v, want only the Go repo example/stale", repos)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/gofrs/help-requests/internal/staleness"
+)
+
+// GiteaForge searches and scores repositories hosted on a Gitea
+// instance.
+type GiteaForge struct {
+	client *gitea.Client
+}
+
+// NewGiteaForge returns a Forge backed by an authenticated Gitea
+// client.
+func NewGiteaForge(client *gitea.Client) *GiteaForge {
+	return &GiteaForge{client: client}
+}
+
+func (f *GiteaForge) Name() string { return "gitea" }
+
+// SearchRepos lists public Gitea repos by star count and filters
+// client-side, since Gitea's search doesn't support GitHub's
+// "stars:>N pushed:<date language:X" query syntax (its SearchRepoOptions
+// has no language parameter, so Language is matched against each
+// result's own Language field instead). Since the filter (pushed
+// before a date) is independent of the sort (stars desc), a single
+// page of the most-starred repos is unlikely to contain q.Limit
+// matches, so we page until we have enough or run out.
+func (f *GiteaForge) SearchRepos(ctx context.Context, q Query) ([]Repo, error) {
+	var repos []Repo
+	for page := 1; page <= maxSearchPages && len(repos) < q.Limit; page++ {
+		results, resp, err := f.client.SearchRepos(gitea.SearchRepoOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: 50},
+			Sort:        "stars",
+			Order:       "desc",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("gitea: searching repositories (page %d): %v", page, err)
+		}
+		if len(results) == 0 {
+			break
+		}
+
+		for _, r := range results {
+			if r.Stars < q.MinStars || r.Updated.After(q.PushedBefore) {
+				continue
+			}
+			if q.Language != "" && !strings.EqualFold(r.Language, q.Language) {
+				continue
+			}
+			repos = append(repos, Repo{
+				Forge:    "gitea",
+				FullName: r.FullName,
+				HTMLURL:  strings.Replace(r.HTMLURL, "https://", "", 1),
+				Stars:    r.Stars,
+				PushedAt: r.Updated,
+				Archived: r.Archived,
+				Native:   r,
+			})
+			if len(repos) >= q.Limit {
+				break
+			}
+		}
+
+		if resp != nil && resp.NextPage == 0 {
+			break
+		}
+	}
+	return repos, nil
+}
+
+// oldestPR returns the creation time of the oldest pr in prs, or nil
+// if prs is empty or none of them report a creation time.
+func oldestPR(prs []*gitea.PullRequest) *time.Time {
+	var oldest *time.Time
+	for _, pr := range prs {
+		if pr.Created == nil {
+			continue
+		}
+		if oldest == nil || pr.Created.Before(*oldest) {
+			oldest = pr.Created
+		}
+	}
+	return oldest
+}
+
+func (f *GiteaForge) RepoStats(ctx context.Context, repo Repo) (staleness.Stats, error) {
+	r, ok := repo.Native.(*gitea.Repository)
+	if !ok {
+		return staleness.Stats{}, fmt.Errorf("gitea: repo %q missing native *gitea.Repository", repo.FullName)
+	}
+	owner, name, err := splitFullName(repo.FullName)
+	if err != nil {
+		return staleness.Stats{}, err
+	}
+
+	stats := staleness.Stats{
+		DaysSinceLastCommit:  daysSince(r.Updated),
+		Archived:             r.Archived,
+		DaysSinceLastRelease: -1,
+	}
+
+	releases, _, err := f.client.ListReleases(owner, name, gitea.ListReleasesOptions{})
+	if err == nil && len(releases) > 0 {
+		stats.DaysSinceLastRelease = daysSince(releases[0].PublishedAt)
+	}
+
+	issues, _, err := f.client.ListRepoIssues(owner, name, gitea.ListIssueOption{
+		State: gitea.StateOpen,
+		Type:  gitea.IssueTypeIssue,
+	})
+	if err != nil {
+		return stats, fmt.Errorf("gitea: listing issues for %s: %v", repo.FullName, err)
+	}
+	for _, issue := range issues {
+		if issue.Comments == 0 && time.Since(issue.Created) > responseWindow {
+			stats.UnansweredIssues++
+		}
+	}
+
+	prs, _, err := f.client.ListRepoPullRequests(owner, name, gitea.ListPullRequestsOptions{
+		State: gitea.StateOpen,
+	})
+	if err != nil {
+		return stats, fmt.Errorf("gitea: listing pull requests for %s: %v", repo.FullName, err)
+	}
+	if oldest := oldestPR(prs); oldest != nil {
+		stats.OpenPRBacklogDays = daysSince(*oldest)
+	}
+
+	return stats, nil
+}
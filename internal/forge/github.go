@@ -0,0 +1,154 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/help-requests/internal/staleness"
+	"github.com/google/go-github/github"
+)
+
+// GitHubForge searches and scores repositories hosted on GitHub.
+type GitHubForge struct {
+	client *github.Client
+}
+
+// NewGitHubForge returns a Forge backed by an authenticated GitHub
+// client.
+func NewGitHubForge(client *github.Client) *GitHubForge {
+	return &GitHubForge{client: client}
+}
+
+func (f *GitHubForge) Name() string { return "github" }
+
+func (f *GitHubForge) SearchRepos(ctx context.Context, q Query) ([]Repo, error) {
+	query := fmt.Sprintf("stars:>%d pushed:<%s language:%s", q.MinStars, q.PushedBefore.Format("2006-01-02"), q.Language)
+	res, _, err := f.client.Search.Repositories(ctx, query, &github.SearchOptions{
+		Sort:        "stars",
+		Order:       "desc",
+		ListOptions: github.ListOptions{PerPage: q.Limit, Page: 1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("github: searching repositories: %v", err)
+	}
+
+	repos := make([]Repo, len(res.Repositories))
+	for i := range res.Repositories {
+		r := res.Repositories[i]
+		repos[i] = Repo{
+			Forge:    "github",
+			FullName: r.GetFullName(),
+			HTMLURL:  strings.Replace(r.GetHTMLURL(), "https://", "", 1),
+			Stars:    r.GetStargazersCount(),
+			PushedAt: r.GetPushedAt().Time,
+			Archived: r.GetArchived(),
+			Native:   r,
+		}
+	}
+	return repos, nil
+}
+
+func (f *GitHubForge) RepoStats(ctx context.Context, repo Repo) (staleness.Stats, error) {
+	r, ok := repo.Native.(*github.Repository)
+	if !ok {
+		return staleness.Stats{}, fmt.Errorf("github: repo %q missing native *github.Repository", repo.FullName)
+	}
+	owner, name, err := splitFullName(repo.FullName)
+	if err != nil {
+		return staleness.Stats{}, err
+	}
+
+	stats := staleness.Stats{
+		DaysSinceLastCommit: daysSince(r.GetPushedAt().Time),
+		Archived:            r.GetArchived(),
+	}
+
+	release, _, err := f.client.Repositories.GetLatestRelease(ctx, owner, name)
+	switch {
+	case err == nil:
+		stats.DaysSinceLastRelease = daysSince(release.GetPublishedAt().Time)
+	default:
+		// No releases (or an API hiccup) isn't fatal to scoring;
+		// just record that there's nothing to measure.
+		stats.DaysSinceLastRelease = -1
+	}
+
+	issues, _, err := f.client.Issues.ListByRepo(ctx, owner, name, &github.IssueListByRepoOptions{
+		State: "open",
+	})
+	if err != nil {
+		return stats, fmt.Errorf("github: listing issues for %s: %v", repo.FullName, err)
+	}
+	for _, issue := range issues {
+		if issue.IsPullRequest() {
+			continue
+		}
+		if issue.GetComments() == 0 && time.Since(issue.GetCreatedAt()) > responseWindow {
+			stats.UnansweredIssues++
+		}
+	}
+
+	prs, _, err := f.client.PullRequests.List(ctx, owner, name, &github.PullRequestListOptions{
+		State:     "open",
+		Sort:      "created",
+		Direction: "asc",
+	})
+	if err != nil {
+		return stats, fmt.Errorf("github: listing pull requests for %s: %v", repo.FullName, err)
+	}
+	if len(prs) > 0 {
+		stats.OpenPRBacklogDays = daysSince(prs[0].GetCreatedAt())
+	}
+
+	return stats, nil
+}
+
+// HasOpenIssue reports whether the authenticated user has already
+// filed an issue titled title on repo, open or closed, so finder
+// doesn't re-file it on a later run.
+func (f *GitHubForge) HasOpenIssue(ctx context.Context, repo Repo, title string) (bool, error) {
+	owner, name, err := splitFullName(repo.FullName)
+	if err != nil {
+		return false, err
+	}
+
+	me, _, err := f.client.Users.Get(ctx, "")
+	if err != nil {
+		return false, fmt.Errorf("github: looking up authenticated user: %v", err)
+	}
+
+	issues, _, err := f.client.Issues.ListByRepo(ctx, owner, name, &github.IssueListByRepoOptions{
+		State:   "all",
+		Creator: me.GetLogin(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("github: listing issues for %s: %v", repo.FullName, err)
+	}
+	for _, issue := range issues {
+		if issue.GetTitle() == title {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *GitHubForge) FileIssue(ctx context.Context, repo Repo, title, body string) error {
+	owner, name, err := splitFullName(repo.FullName)
+	if err != nil {
+		return err
+	}
+	_, _, err = f.client.Issues.Create(ctx, owner, name, &github.IssueRequest{
+		Title: github.String(title),
+		Body:  github.String(body),
+	})
+	if err != nil {
+		return fmt.Errorf("github: filing issue on %s: %v", repo.FullName, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/help-requests/internal/staleness"
+	"github.com/xanzy/go-gitlab"
+)
+
+// GitLabForge searches and scores repositories hosted on GitLab.
+type GitLabForge struct {
+	client *gitlab.Client
+}
+
+// NewGitLabForge returns a Forge backed by an authenticated GitLab
+// client.
+func NewGitLabForge(client *gitlab.Client) *GitLabForge {
+	return &GitLabForge{client: client}
+}
+
+func (f *GitLabForge) Name() string { return "gitlab" }
+
+// maxSearchPages bounds how many pages SearchRepos will walk looking
+// for matches, so an instance with few (or no) stale projects doesn't
+// make finder page through its entire project list.
+const maxSearchPages = 50
+
+// SearchRepos lists public GitLab projects by star count, filtered
+// server-side by language (GitLab's with_programming_language search
+// parameter) and client-side by MinStars/PushedBefore, since neither
+// is exposed by the search API. Since the PushedBefore filter is
+// independent of the sort (stars desc), a single page of the
+// most-starred projects is unlikely to contain q.Limit matches, so we
+// page until we have enough or run out.
+func (f *GitLabForge) SearchRepos(ctx context.Context, q Query) ([]Repo, error) {
+	opts := &gitlab.ListProjectsOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 100, Page: 1},
+		OrderBy:     gitlab.String("star_count"),
+		Sort:        gitlab.String("desc"),
+		Visibility:  gitlab.Visibility(gitlab.PublicVisibility),
+	}
+	if q.Language != "" {
+		opts.WithProgrammingLanguage = gitlab.String(q.Language)
+	}
+
+	var repos []Repo
+	for page := 1; page <= maxSearchPages && len(repos) < q.Limit; page++ {
+		opts.Page = page
+		projects, resp, err := f.client.Projects.ListProjects(opts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("gitlab: listing projects (page %d): %v", page, err)
+		}
+		if len(projects) == 0 {
+			break
+		}
+
+		for _, p := range projects {
+			if p.StarCount < q.MinStars {
+				continue
+			}
+			lastActivity := gitlabTime(p.LastActivityAt)
+			if lastActivity.After(q.PushedBefore) {
+				continue
+			}
+			repos = append(repos, Repo{
+				Forge:    "gitlab",
+				FullName: p.PathWithNamespace,
+				HTMLURL:  strings.Replace(p.WebURL, "https://", "", 1),
+				Stars:    p.StarCount,
+				PushedAt: lastActivity,
+				Archived: p.Archived,
+				Native:   p,
+			})
+			if len(repos) >= q.Limit {
+				break
+			}
+		}
+
+		if resp != nil && resp.NextPage == 0 {
+			break
+		}
+	}
+	return repos, nil
+}
+
+func (f *GitLabForge) RepoStats(ctx context.Context, repo Repo) (staleness.Stats, error) {
+	p, ok := repo.Native.(*gitlab.Project)
+	if !ok {
+		return staleness.Stats{}, fmt.Errorf("gitlab: repo %q missing native *gitlab.Project", repo.FullName)
+	}
+
+	stats := staleness.Stats{
+		DaysSinceLastCommit:  daysSince(gitlabTime(p.LastActivityAt)),
+		Archived:             p.Archived,
+		DaysSinceLastRelease: -1,
+	}
+
+	releases, _, err := f.client.Releases.ListReleases(p.ID, &gitlab.ListReleasesOptions{}, gitlab.WithContext(ctx))
+	if err == nil && len(releases) > 0 && releases[0].ReleasedAt != nil {
+		stats.DaysSinceLastRelease = daysSince(*releases[0].ReleasedAt)
+	}
+
+	issues, _, err := f.client.Issues.ListProjectIssues(p.ID, &gitlab.ListProjectIssuesOptions{
+		State: gitlab.String("opened"),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return stats, fmt.Errorf("gitlab: listing issues for %s: %v", repo.FullName, err)
+	}
+	for _, issue := range issues {
+		if issue.UserNotesCount == 0 && issue.CreatedAt != nil && time.Since(*issue.CreatedAt) > responseWindow {
+			stats.UnansweredIssues++
+		}
+	}
+
+	mrs, _, err := f.client.MergeRequests.ListProjectMergeRequests(p.ID, &gitlab.ListProjectMergeRequestsOptions{
+		State:   gitlab.String("opened"),
+		OrderBy: gitlab.String("created_at"),
+		Sort:    gitlab.String("asc"),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return stats, fmt.Errorf("gitlab: listing merge requests for %s: %v", repo.FullName, err)
+	}
+	if len(mrs) > 0 && mrs[0].CreatedAt != nil {
+		stats.OpenPRBacklogDays = daysSince(*mrs[0].CreatedAt)
+	}
+
+	return stats, nil
+}
+
+func gitlabTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
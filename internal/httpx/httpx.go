@@ -0,0 +1,126 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+// Package httpx provides the rate-limiting and on-disk caching
+// transports shared by finder's forge and importer HTTP clients.
+package httpx
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gregjones/httpcache"
+	"github.com/gregjones/httpcache/diskcache"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitedTransport throttles outgoing requests to limiter's rate
+// before delegating to the wrapped RoundTripper.
+type RateLimitedTransport struct {
+	Limiter   *rate.Limiter
+	Transport http.RoundTripper
+}
+
+func (t *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.Transport.RoundTrip(req)
+}
+
+// CacheDir returns the directory backing the on-disk HTTP cache,
+// honoring $XDG_CACHE_HOME and falling back to the OS default cache
+// directory when it isn't set.
+func CacheDir() (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		base = dir
+	}
+	return filepath.Join(base, "gofrs-finder"), nil
+}
+
+// WrapWithCache returns transport unmodified if noCache is set, or
+// wraps it in a conditional-request cache under CacheDir otherwise.
+// GitHub, GitLab, and Gitea all honor ETag/If-None-Match and return
+// 304s that don't consume rate-limit quota; pages that don't send a
+// validator (like pkg.go.dev's importedby page) are bounded by ttl
+// instead via ttlCache.
+func WrapWithCache(transport http.RoundTripper, noCache bool, ttl time.Duration) (http.RoundTripper, error) {
+	if noCache {
+		return transport, nil
+	}
+	dir, err := CacheDir()
+	if err != nil {
+		return transport, err
+	}
+	cache := &ttlCache{Cache: diskcache.New(dir), ttl: ttl}
+	t := httpcache.NewTransport(cache)
+	t.Transport = transport
+	return t, nil
+}
+
+// ttlCache wraps an httpcache.Cache and expires entries older than
+// ttl, giving us stale-while-revalidate behavior for responses that
+// don't send their own validator (ETag or Last-Modified) for
+// httpcache to key off of. Entries that do carry a validator are left
+// alone indefinitely: expiring them here would just force a full
+// request where a conditional If-None-Match/If-Modified-Since one
+// would have done, which is exactly the rate-limit cost caching was
+// meant to avoid.
+type ttlCache struct {
+	httpcache.Cache
+	ttl time.Duration
+}
+
+func (c *ttlCache) Get(key string) ([]byte, bool) {
+	resp, ok := c.Cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if hasValidator(resp) {
+		return resp, true
+	}
+
+	stampKey := key + ":stamp"
+	raw, ok := c.Cache.Get(stampKey)
+	if !ok {
+		// No stamp means this entry predates TTL tracking; treat it
+		// as fresh rather than discarding a perfectly good cache.
+		return resp, true
+	}
+	stamp, err := time.Parse(time.RFC3339, string(raw))
+	if err != nil || time.Since(stamp) > c.ttl {
+		return nil, false
+	}
+	return resp, true
+}
+
+// hasValidator reports whether raw, a dumped HTTP response as stored
+// by httpcache, carries an ETag or Last-Modified header.
+func hasValidator(raw []byte) bool {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(raw)), nil)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.Header.Get("Etag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+func (c *ttlCache) Set(key string, resp []byte) {
+	c.Cache.Set(key, resp)
+	c.Cache.Set(key+":stamp", []byte(time.Now().Format(time.RFC3339)))
+}
+
+func (c *ttlCache) Delete(key string) {
+	c.Cache.Delete(key)
+	c.Cache.Delete(key + ":stamp")
+}
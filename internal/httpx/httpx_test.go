@@ -0,0 +1,77 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"testing"
+	"time"
+
+	"github.com/gregjones/httpcache"
+)
+
+// dumpResponse builds the raw bytes httpcache stores for a response,
+// optionally carrying an ETag validator.
+func dumpResponse(t *testing.T, etag string) []byte {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	if etag != "" {
+		rec.Header().Set("Etag", etag)
+	}
+	rec.WriteHeader(http.StatusOK)
+	rec.WriteString("body")
+
+	raw, err := httputil.DumpResponse(rec.Result(), true)
+	if err != nil {
+		t.Fatalf("dumping response: %v", err)
+	}
+	return raw
+}
+
+func TestTTLCacheGetValidatorEntriesNeverExpire(t *testing.T) {
+	c := &ttlCache{Cache: httpcache.NewMemoryCache(), ttl: time.Millisecond}
+	c.Set("key", dumpResponse(t, `"abc123"`))
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("key"); !ok {
+		t.Error("Get() = false, want true: validator-bearing entries shouldn't be TTL-expired")
+	}
+}
+
+func TestTTLCacheGetExpiresEntriesWithoutValidator(t *testing.T) {
+	c := &ttlCache{Cache: httpcache.NewMemoryCache(), ttl: time.Millisecond}
+	c.Set("key", dumpResponse(t, ""))
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("key"); ok {
+		t.Error("Get() = true, want false: validator-less entries should expire after ttl")
+	}
+}
+
+func TestTTLCacheGetFreshEntryWithoutValidator(t *testing.T) {
+	c := &ttlCache{Cache: httpcache.NewMemoryCache(), ttl: time.Hour}
+	c.Set("key", dumpResponse(t, ""))
+
+	if _, ok := c.Get("key"); !ok {
+		t.Error("Get() = false, want true: entry is well within ttl")
+	}
+}
+
+func TestTTLCacheDelete(t *testing.T) {
+	c := &ttlCache{Cache: httpcache.NewMemoryCache(), ttl: time.Hour}
+	c.Set("key", dumpResponse(t, ""))
+	c.Delete("key")
+
+	if _, ok := c.Get("key"); ok {
+		t.Error("Get() = true after Delete, want false")
+	}
+	if _, ok := c.Cache.Get("key:stamp"); ok {
+		t.Error("stamp entry survived Delete")
+	}
+}
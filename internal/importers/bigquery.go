@@ -0,0 +1,71 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package importers
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+)
+
+// BigQueryCounter counts importers by querying the public
+// bigquery-public-data.deps_dev_v1 dataset for reverse dependencies
+// of a Go module path.
+type BigQueryCounter struct {
+	client *bigquery.Client
+}
+
+// NewBigQueryCounter returns a Counter that runs queries against the
+// given GCP project. The project only needs to be able to run (and
+// pay for) the query; the dataset itself is public.
+func NewBigQueryCounter(ctx context.Context, project string) (*BigQueryCounter, error) {
+	if project == "" {
+		return nil, fmt.Errorf("importers: bigquery project is required")
+	}
+	client, err := bigquery.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("importers: creating bigquery client: %v", err)
+	}
+	return &BigQueryCounter{client: client}, nil
+}
+
+func (c *BigQueryCounter) Close() error {
+	return c.client.Close()
+}
+
+const reverseDepsQuery = `
+SELECT COUNT(DISTINCT Dependent.Name) AS importers
+FROM ` + "`bigquery-public-data.deps_dev_v1.Dependencies`" + `
+WHERE System = 'GO' AND Dependency.Name = @importPath
+`
+
+func (c *BigQueryCounter) Count(ctx context.Context, importPath string) (int, error) {
+	q := c.client.Query(reverseDepsQuery)
+	q.Parameters = []bigquery.QueryParameter{
+		{Name: "importPath", Value: importPath},
+	}
+
+	it, err := q.Read(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("importers: running bigquery query for %s: %v", importPath, err)
+	}
+
+	var row struct {
+		Importers int64
+	}
+	if err := it.Next(&row); err != nil {
+		if err == iterator.Done {
+			return -1, fmt.Errorf("importers: %w: %s", ErrNotFound, importPath)
+		}
+		return -1, fmt.Errorf("importers: reading bigquery result for %s: %v", importPath, err)
+	}
+	// COUNT always yields a row, so an unknown path shows up as 0.
+	if row.Importers == 0 {
+		return -1, fmt.Errorf("importers: %w: %s", ErrNotFound, importPath)
+	}
+	return int(row.Importers), nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+// Package importers counts how many other modules import a given Go
+// import path. godoc.org used to expose this on its package page, but
+// it has been retired and redirected to pkg.go.dev, which requires a
+// different approach to get an exact count.
+package importers
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by a Counter when the import path has no
+// known reverse-dependency data (e.g. it's never been indexed).
+var ErrNotFound = errors.New("importers: import path not found")
+
+// Counter returns the number of packages importing importPath.
+type Counter interface {
+	// Count returns the number of known importers of importPath, or
+	// an error if the count couldn't be determined.
+	Count(ctx context.Context, importPath string) (int, error)
+}
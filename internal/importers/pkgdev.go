@@ -0,0 +1,128 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package importers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// PkgGoDevCounter counts importers by scraping the "Imported By" tab
+// of a package's page on pkg.go.dev, paginating through the importer
+// list so the count is exact rather than the rounded figure shown in
+// the page heading.
+type PkgGoDevCounter struct {
+	// Client is the HTTP client used to fetch pkg.go.dev pages. If
+	// nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// NewPkgGoDevCounter returns a Counter backed by pkg.go.dev.
+func NewPkgGoDevCounter(client *http.Client) *PkgGoDevCounter {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &PkgGoDevCounter{Client: client}
+}
+
+func (c *PkgGoDevCounter) Count(ctx context.Context, importPath string) (int, error) {
+	total := 0
+	pageURL := "https://pkg.go.dev/" + importPath + "?tab=importedby"
+	for pageURL != "" {
+		n, next, err := c.countPage(ctx, pageURL)
+		if err != nil {
+			return -1, err
+		}
+		total += n
+		pageURL = next
+	}
+	if total == 0 {
+		return -1, fmt.Errorf("importers: %w: %s", ErrNotFound, importPath)
+	}
+	return total, nil
+}
+
+// countPage fetches a single importedby page and returns the number
+// of importer rows on it along with the URL of the next page, if any.
+func (c *PkgGoDevCounter) countPage(ctx context.Context, pageURL string) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return 0, "", fmt.Errorf("importers: building request for %s: %v", pageURL, err)
+	}
+	req.Header.Set("User-Agent", "Gofrs popstalerepo bot")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("importers: fetching %s: %v", pageURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, "", fmt.Errorf("importers: %s returned %s", pageURL, resp.Status)
+	}
+
+	doc, err := html.Parse(resp.Body)
+	if err != nil {
+		return 0, "", fmt.Errorf("importers: parsing %s: %v", pageURL, err)
+	}
+
+	count := 0
+	var nextHref string
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "li":
+				if hasClass(n, "u-breakWord") {
+					count++
+				}
+			case "a":
+				for _, a := range n.Attr {
+					if a.Key == "href" && strings.Contains(a.Val, "tab=importedby") && strings.Contains(a.Val, "page=") {
+						if isNextLink(n) {
+							nextHref = a.Val
+						}
+					}
+				}
+			}
+		}
+		for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+			walk(ch)
+		}
+	}
+	walk(doc)
+
+	next := ""
+	if nextHref != "" {
+		if u, err := url.Parse(nextHref); err == nil {
+			next = "https://pkg.go.dev" + u.RequestURI()
+		}
+	}
+	return count, next, nil
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, a := range n.Attr {
+		if a.Key == "class" && strings.Contains(a.Val, class) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNextLink(n *html.Node) bool {
+	if n.FirstChild == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(n.FirstChild.Data), "next")
+}
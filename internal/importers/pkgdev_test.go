@@ -0,0 +1,92 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package importers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// page1HTML has two importer rows and a "Next" link to page 2.
+const page1HTML = `<!DOCTYPE html>
+<html><body>
+<ul>
+<li class="u-breakWord">example.com/one</li>
+<li class="u-breakWord">example.com/two</li>
+</ul>
+<a href="/example.com/mod?tab=importedby&page=2">Next</a>
+</body></html>`
+
+// page2HTML has one importer row and no further pages.
+const page2HTML = `<!DOCTYPE html>
+<html><body>
+<ul>
+<li class="u-breakWord">example.com/three</li>
+</ul>
+<a href="/example.com/mod?tab=importedby&page=1">Previous</a>
+</body></html>`
+
+func TestPkgGoDevCounterCountPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.RawQuery, "page=2"):
+			w.Write([]byte(page2HTML))
+		default:
+			w.Write([]byte(page1HTML))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewPkgGoDevCounter(srv.Client())
+
+	n, next, err := c.countPage(context.Background(), srv.URL+"/example.com/mod?tab=importedby")
+	if err != nil {
+		t.Fatalf("countPage (page 1): %v", err)
+	}
+	if n != 2 {
+		t.Errorf("countPage (page 1) count = %d, want 2", n)
+	}
+	if !strings.Contains(next, "page=2") {
+		t.Errorf("countPage (page 1) next = %q, want it to reference page=2", next)
+	}
+
+	// countPage always rewrites the host of the next link to
+	// https://pkg.go.dev (see pkgdev.go), so point it back at srv to
+	// keep the test hermetic instead of hitting the real site.
+	u, err := url.Parse(next)
+	if err != nil {
+		t.Fatalf("parsing next URL %q: %v", next, err)
+	}
+	n, next, err = c.countPage(context.Background(), srv.URL+u.RequestURI())
+	if err != nil {
+		t.Fatalf("countPage (page 2): %v", err)
+	}
+	if n != 1 {
+		t.Errorf("countPage (page 2) count = %d, want 1", n)
+	}
+	if next != "" {
+		t.Errorf("countPage (page 2) next = %q, want empty: the only link is a Previous one", next)
+	}
+}
+
+func TestPkgGoDevCounterCountPageNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewPkgGoDevCounter(srv.Client())
+	n, next, err := c.countPage(context.Background(), srv.URL+"/example.com/missing?tab=importedby")
+	if err != nil {
+		t.Fatalf("countPage: %v", err)
+	}
+	if n != 0 || next != "" {
+		t.Errorf("countPage on a 404 = (%d, %q), want (0, \"\")", n, next)
+	}
+}
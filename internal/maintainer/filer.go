@@ -0,0 +1,98 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package maintainer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/help-requests/internal/forge"
+)
+
+// IssueTitle is the title finder uses for the issues it files, and
+// the title it searches for to detect ones it's already filed.
+const IssueTitle = "Seeking a new maintainer"
+
+// IssueBody returns the body finder files on repo's help-wanted issue.
+func IssueBody(repo forge.Repo) string {
+	return fmt.Sprintf(`This repository hasn't seen commits, releases, or issue responses in a while, and it showed up in a scan of popular but seemingly unmaintained Go projects.
+
+If you're a maintainer and this project is still active, feel free to close this issue. Otherwise, if you rely on %s and would be willing to help maintain it, please comment below - we're trying to connect stale, widely-imported projects with volunteers.
+
+_Filed automatically by the gofrs/help-requests finder tool._`, repo.FullName)
+}
+
+// Filer opens help-wanted issues on stale repos, with safeguards
+// against duplicate or runaway filing.
+type Filer struct {
+	// Forges maps a forge name (as in Repo.Forge) to the IssueFiler
+	// that can file issues on it. Forges without an entry here are
+	// skipped with an error.
+	Forges map[string]forge.IssueFiler
+	// Ledger tracks repos finder has already filed issues on.
+	Ledger *Ledger
+	// ImporterThreshold is the minimum import count a repo needs
+	// before finder will file an issue on it.
+	ImporterThreshold int
+	// MaxPerRun caps how many new issues a single run can file.
+	MaxPerRun int
+
+	mu    sync.Mutex
+	filed int
+}
+
+// FileIfStale files a help-wanted issue on repo if it's eligible: not
+// archived or disabled, at or above ImporterThreshold importers, not
+// already in the ledger or already filed on the forge itself, and
+// under MaxPerRun for this run. It reports whether it filed a new
+// issue.
+func (f *Filer) FileIfStale(ctx context.Context, repo forge.Repo, importCount int) (bool, error) {
+	if repo.Archived || repo.Disabled {
+		return false, nil
+	}
+	if importCount < f.ImporterThreshold {
+		return false, nil
+	}
+
+	key := repo.Forge + ":" + repo.FullName
+	if f.Ledger.Has(key) {
+		return false, nil
+	}
+
+	filer, ok := f.Forges[repo.Forge]
+	if !ok {
+		return false, fmt.Errorf("maintainer: forge %q doesn't support filing issues", repo.Forge)
+	}
+
+	has, err := filer.HasOpenIssue(ctx, repo, IssueTitle)
+	if err != nil {
+		return false, err
+	}
+	if has {
+		return false, f.Ledger.Record(key, Entry{FiledAt: time.Now()})
+	}
+
+	if !f.reserveSlot() {
+		return false, nil
+	}
+	if err := filer.FileIssue(ctx, repo, IssueTitle, IssueBody(repo)); err != nil {
+		return false, err
+	}
+	return true, f.Ledger.Record(key, Entry{FiledAt: time.Now()})
+}
+
+// reserveSlot claims one of MaxPerRun filing slots for this run,
+// reporting false once they've all been claimed.
+func (f *Filer) reserveSlot() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.filed >= f.MaxPerRun {
+		return false
+	}
+	f.filed++
+	return true
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+// Package maintainer automates filing "seeking a new maintainer"
+// issues on stale, widely-imported repos finder discovers.
+package maintainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry records that finder has already filed (or found an existing)
+// help-wanted issue on a repo.
+type Entry struct {
+	FiledAt time.Time `json:"filed_at"`
+}
+
+// Ledger is a JSON-backed record of repos finder has already filed
+// help-wanted issues on, keyed by "<forge>:<owner>/<name>", so reruns
+// don't file duplicates even if GitHub's issue search misses one.
+type Ledger struct {
+	path string
+
+	mu      sync.Mutex
+	Entries map[string]Entry `json:"entries"`
+}
+
+// LoadLedger reads the ledger at path, returning an empty one if the
+// file doesn't exist yet.
+func LoadLedger(path string) (*Ledger, error) {
+	l := &Ledger{path: path, Entries: map[string]Entry{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("maintainer: reading ledger %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("maintainer: parsing ledger %s: %v", path, err)
+	}
+	return l, nil
+}
+
+// Has reports whether key already has a ledger entry.
+func (l *Ledger) Has(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.Entries[key]
+	return ok
+}
+
+// Record adds key to the ledger and persists it to disk.
+func (l *Ledger) Record(key string, entry Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Entries[key] = entry
+	return l.save()
+}
+
+// save must be called with l.mu held.
+func (l *Ledger) save() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("maintainer: creating ledger directory: %v", err)
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("maintainer: marshaling ledger: %v", err)
+	}
+	if err := os.WriteFile(l.path, data, 0o644); err != nil {
+		return fmt.Errorf("maintainer: writing ledger %s: %v", l.path, err)
+	}
+	return nil
+}
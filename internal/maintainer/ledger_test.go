@@ -0,0 +1,46 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package maintainer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadLedgerMissingFile(t *testing.T) {
+	l, err := LoadLedger(filepath.Join(t.TempDir(), "nonexistent.json"))
+	if err != nil {
+		t.Fatalf("LoadLedger: %v", err)
+	}
+	if l.Has("github:gofrs/uuid") {
+		t.Error("Has() = true on an empty ledger, want false")
+	}
+}
+
+func TestLedgerRecordAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filed-issues.json")
+
+	l, err := LoadLedger(path)
+	if err != nil {
+		t.Fatalf("LoadLedger: %v", err)
+	}
+
+	key := "github:gofrs/uuid"
+	if err := l.Record(key, Entry{FiledAt: time.Now()}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	reloaded, err := LoadLedger(path)
+	if err != nil {
+		t.Fatalf("LoadLedger (reload): %v", err)
+	}
+	if !reloaded.Has(key) {
+		t.Error("Has() = false after reload, want true")
+	}
+	if reloaded.Has("github:gofrs/other") {
+		t.Error("Has() = true for an unrecorded key, want false")
+	}
+}
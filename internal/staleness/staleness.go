@@ -0,0 +1,38 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+// Package staleness scores how likely a repository is to be
+// unmaintained, combining more signals than just its last push date.
+package staleness
+
+// Stats holds the raw per-repo signals a Scorer combines into a
+// single staleness score.
+type Stats struct {
+	// DaysSinceLastCommit is the age, in days, of the latest activity
+	// the forge reports for the repo: GitHub's last push, GitLab's
+	// last activity, or Gitea's last update. None of these is limited
+	// to commits on the default branch. It is -1 if the forge reports
+	// no time.
+	DaysSinceLastCommit int
+	// DaysSinceLastRelease is the age of the most recent release, in
+	// days, or -1 if the repo has never published one or the release
+	// lookup failed.
+	DaysSinceLastRelease int
+	// UnansweredIssues is the number of open issues that have no
+	// comments at all and were opened longer ago than the forge
+	// package's response window.
+	UnansweredIssues int
+	// OpenPRBacklogDays is the age of the oldest open pull request,
+	// in days, or 0 if there are none open.
+	OpenPRBacklogDays int
+	// Archived repos are never considered stale; GitHub already
+	// marks them as no longer maintained.
+	Archived bool
+}
+
+// Scorer turns a repo's Stats into a single staleness score. Higher
+// scores mean more likely to be abandoned.
+type Scorer interface {
+	Score(Stats) float64
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package staleness
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Weights controls how much each signal in Stats contributes to a
+// WeightedScorer's final score.
+type Weights struct {
+	LastCommit  float64 `json:"last_commit" yaml:"last_commit"`
+	LastRelease float64 `json:"last_release" yaml:"last_release"`
+	StaleIssues float64 `json:"stale_issues" yaml:"stale_issues"`
+	PRBacklog   float64 `json:"pr_backlog" yaml:"pr_backlog"`
+}
+
+// DefaultWeights weights commit age most heavily of the day-based
+// signals, since it's the clearest single signal, with the others as
+// supporting evidence. Each unanswered issue counts as much as two
+// days of commit age.
+func DefaultWeights() Weights {
+	return Weights{
+		LastCommit:  1.0,
+		LastRelease: 0.5,
+		StaleIssues: 2.0,
+		PRBacklog:   0.25,
+	}
+}
+
+// LoadWeights reads Weights from a YAML or JSON file, chosen by the
+// file's extension.
+func LoadWeights(path string) (Weights, error) {
+	var w Weights
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return w, fmt.Errorf("staleness: reading %s: %v", path, err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &w)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &w)
+	default:
+		return w, fmt.Errorf("staleness: unrecognized config extension %q", ext)
+	}
+	if err != nil {
+		return w, fmt.Errorf("staleness: parsing %s: %v", path, err)
+	}
+	return w, nil
+}
+
+// WeightedScorer scores Stats as a weighted sum of its fields.
+// Archived repos always score 0, since they're explicitly
+// unmaintained rather than merely neglected.
+type WeightedScorer struct {
+	Weights Weights
+}
+
+// NewWeightedScorer returns a WeightedScorer using the given weights.
+func NewWeightedScorer(w Weights) *WeightedScorer {
+	return &WeightedScorer{Weights: w}
+}
+
+func (s *WeightedScorer) Score(stats Stats) float64 {
+	if stats.Archived {
+		return 0
+	}
+	score := float64(stats.DaysSinceLastCommit) * s.Weights.LastCommit
+	score += float64(stats.UnansweredIssues) * s.Weights.StaleIssues
+	score += float64(stats.OpenPRBacklogDays) * s.Weights.PRBacklog
+	if stats.DaysSinceLastRelease >= 0 {
+		score += float64(stats.DaysSinceLastRelease) * s.Weights.LastRelease
+	}
+	return score
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 The Gofrs. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package staleness
+
+import "testing"
+
+func TestWeightedScorerScore(t *testing.T) {
+	scorer := NewWeightedScorer(Weights{
+		LastCommit:  1.0,
+		LastRelease: 0.5,
+		StaleIssues: 2.0,
+		PRBacklog:   0.25,
+	})
+
+	tests := []struct {
+		name  string
+		stats Stats
+		want  float64
+	}{
+		{
+			name:  "archived always scores zero",
+			stats: Stats{Archived: true, DaysSinceLastCommit: 1000, UnansweredIssues: 10},
+			want:  0,
+		},
+		{
+			name: "no release is excluded from the sum",
+			stats: Stats{
+				DaysSinceLastCommit:  100,
+				DaysSinceLastRelease: -1,
+				UnansweredIssues:     2,
+				OpenPRBacklogDays:    4,
+			},
+			want: 100*1.0 + 2*2.0 + 4*0.25,
+		},
+		{
+			name: "all signals contribute",
+			stats: Stats{
+				DaysSinceLastCommit:  100,
+				DaysSinceLastRelease: 50,
+				UnansweredIssues:     2,
+				OpenPRBacklogDays:    4,
+			},
+			want: 100*1.0 + 50*0.5 + 2*2.0 + 4*0.25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scorer.Score(tt.stats); got != tt.want {
+				t.Errorf("Score(%+v) = %v, want %v", tt.stats, got, tt.want)
+			}
+		})
+	}
+}